@@ -0,0 +1,305 @@
+// Package handler holds the Fiber controllers for the expenses API. It
+// parses requests into service-layer inputs, maps service errors to HTTP
+// responses, and otherwise defers all business logic to the service.
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"expenses-tracker-go/service"
+	"expenses-tracker-go/user"
+)
+
+// ExpenseHandler exposes the expenses API on top of an ExpenseService.
+type ExpenseHandler struct {
+	svc *service.ExpenseService
+}
+
+// NewExpenseHandler wires an ExpenseHandler to its service.
+func NewExpenseHandler(svc *service.ExpenseService) *ExpenseHandler {
+	return &ExpenseHandler{svc: svc}
+}
+
+func getErrorResp(err error) fiber.Map {
+	return fiber.Map{
+		"success": false,
+		"message": fmt.Sprint(err),
+	}
+}
+
+// serviceErrorStatus maps a service error to the HTTP status it should be
+// reported with.
+func serviceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, service.ErrInvalidID),
+		errors.Is(err, service.ErrCostNotPositive),
+		errors.Is(err, service.ErrDescriptionEmpty),
+		errors.Is(err, service.ErrInvalidSortColumn),
+		errors.Is(err, service.ErrInvalidSortOrder),
+		errors.Is(err, service.ErrInvalidLimit):
+		return fiber.StatusBadRequest
+	default:
+		return fiber.StatusBadRequest
+	}
+}
+
+// GetExpenses handles GET /api/expenses: conditional caching plus
+// pagination, sorting, filtering, and aggregate summaries.
+func (h *ExpenseHandler) GetExpenses(c *fiber.Ctx) error {
+	userID, ok := user.UserIDFromContext(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	params, err := parseListParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(getErrorResp(err))
+	}
+
+	if cacheExpenses(c, h.svc.LastModified(userID), string(c.Request().URI().QueryString())) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	result, err := h.svc.List(c.Context(), userID, params)
+	if err != nil {
+		return c.Status(serviceErrorStatus(err)).JSON(getErrorResp(err))
+	}
+
+	return c.JSON(newExpenseCollection(result))
+}
+
+// sseHeartbeatInterval is how often StreamExpenses writes a comment line to
+// an otherwise idle connection, so a client that disconnected without any
+// events being published is still noticed and reaped.
+const sseHeartbeatInterval = 15 * time.Second
+
+// StreamExpenses handles GET /api/expenses/stream, keeping the connection
+// open and pushing create/update/delete events as they happen.
+func (h *ExpenseHandler) StreamExpenses(c *fiber.Ctx) error {
+	userID, ok := user.UserIDFromContext(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	id, ch := h.svc.Subscribe(userID, c.Get("X-Request-Source"))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer h.svc.Unsubscribe(id)
+
+		heartbeat := time.NewTicker(sseHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case event, ok := <-ch:
+				if !ok {
+					return
+				}
+
+				payload, err := json.Marshal(newEventPayload(event))
+				if err != nil {
+					continue
+				}
+
+				if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Action, payload); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				// A disconnected client has no pending events to surface the
+				// write error, so a periodic comment line is what actually
+				// notices it and lets the broker reap the subscriber.
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+// CreateExpense handles POST /api/expenses.
+func (h *ExpenseHandler) CreateExpense(c *fiber.Ctx) error {
+	userID, ok := user.UserIDFromContext(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	in := service.ExpenseCreateUpdate{}
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(getErrorResp(err))
+	}
+
+	display, err := h.svc.Create(c.Context(), userID, in, c.Get("X-Request-Source"))
+	if err != nil {
+		return c.Status(serviceErrorStatus(err)).JSON(getErrorResp(err))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(newExpenseResource(*display))
+}
+
+// UpdateExpense handles PATCH /api/expenses/:id.
+func (h *ExpenseHandler) UpdateExpense(c *fiber.Ctx) error {
+	userID, ok := user.UserIDFromContext(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	in := service.ExpenseCreateUpdate{}
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(getErrorResp(err))
+	}
+
+	display, err := h.svc.Update(c.Context(), userID, c.Params("id"), in, c.Get("X-Request-Source"))
+	if err != nil {
+		return c.Status(serviceErrorStatus(err)).JSON(getErrorResp(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newExpenseResource(*display))
+}
+
+// RemoveExpense handles DELETE /api/expenses/:id.
+func (h *ExpenseHandler) RemoveExpense(c *fiber.Ctx) error {
+	userID, ok := user.UserIDFromContext(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	if err := h.svc.Delete(c.Context(), userID, c.Params("id"), c.Get("X-Request-Source")); err != nil {
+		return c.Status(serviceErrorStatus(err)).JSON(getErrorResp(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"success": true})
+}
+
+// parseListParams translates the GET /api/expenses query parameters into a
+// service.ListParams, reporting malformed values as errors.
+func parseListParams(c *fiber.Ctx) (service.ListParams, error) {
+	params := service.ListParams{
+		Limit:               service.DefaultLimit,
+		SortOrder:           c.Query("sort_order"),
+		SortColumn:          c.Query("sort_column"),
+		DescriptionContains: c.Query("description_contains"),
+	}
+
+	if v := c.Query("limit"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return service.ListParams{}, fmt.Errorf("`limit` must be an integer")
+		}
+		params.Limit = parsed
+	}
+
+	if v := c.Query("offset"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed < 0 {
+			return service.ListParams{}, fmt.Errorf("`offset` must be a non-negative integer")
+		}
+		params.Offset = parsed
+	}
+
+	if v := c.Query("min_cost"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return service.ListParams{}, fmt.Errorf("`min_cost` must be a number")
+		}
+		minCost := float32(parsed)
+		params.MinCost = &minCost
+	}
+
+	if v := c.Query("max_cost"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return service.ListParams{}, fmt.Errorf("`max_cost` must be a number")
+		}
+		maxCost := float32(parsed)
+		params.MaxCost = &maxCost
+	}
+
+	if v := c.Query("from"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return service.ListParams{}, fmt.Errorf("`from` must be an RFC3339 date")
+		}
+		params.From = &parsed
+	}
+
+	if v := c.Query("to"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return service.ListParams{}, fmt.Errorf("`to` must be an RFC3339 date")
+		}
+		params.To = &parsed
+	}
+
+	return params, nil
+}
+
+// cacheExpenses sets Last-Modified/ETag headers for the requested
+// representation of the expenses collection (query included, since
+// pagination/sorting/filtering each produce a different body for the same
+// lastEdit) and reports whether the client's cached copy is still current,
+// in which case the caller should respond with 304.
+func cacheExpenses(c *fiber.Ctx, lastEdit time.Time, query string) bool {
+	etag := expensesETag(lastEdit, query)
+	c.Set("Last-Modified", lastEdit.UTC().Format(http.TimeFormat))
+	c.Set("ETag", etag)
+
+	// A request carrying If-None-Match is asking about this exact
+	// representation, so it takes precedence over If-Modified-Since, which
+	// only knows about lastEdit and can't tell representations apart.
+	if inm := c.Get("If-None-Match"); inm != "" {
+		return inm == etag
+	}
+
+	if since := c.Get("If-Modified-Since"); since != "" {
+		if t, err := http.ParseTime(since); err == nil && !lastEdit.After(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expensesETag folds the query string into the ETag so that two different
+// filtered/paginated/sorted views of the same collection never share a
+// cache-validator, even though they're both driven by the same lastEdit.
+func expensesETag(lastEdit time.Time, query string) string {
+	h := fnv.New64a()
+	h.Write([]byte(query))
+
+	return fmt.Sprintf(`"%x-%x"`, lastEdit.UnixNano(), h.Sum64())
+}
+
+// eventPayload is the JSON shape pushed over SSE for a service.Event.
+type eventPayload struct {
+	Object  string                  `json:"object"`
+	Action  string                  `json:"action"`
+	Expense *service.ExpenseDisplay `json:"expense,omitempty"`
+	ID      string                  `json:"id,omitempty"`
+}
+
+func newEventPayload(event service.Event) eventPayload {
+	return eventPayload{Object: event.Object, Action: event.Action, Expense: event.Expense, ID: event.ID}
+}
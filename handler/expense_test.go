@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"expenses-tracker-go/service"
+)
+
+func TestServiceErrorStatus(t *testing.T) {
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{service.ErrNotFound, fiber.StatusNotFound},
+		{service.ErrInvalidID, fiber.StatusBadRequest},
+		{service.ErrCostNotPositive, fiber.StatusBadRequest},
+		{errors.New("some other error"), fiber.StatusBadRequest},
+	}
+
+	for _, c := range cases {
+		if got := serviceErrorStatus(c.err); got != c.want {
+			t.Errorf("serviceErrorStatus(%v) = %d, want %d", c.err, got, c.want)
+		}
+	}
+}
+
+func TestParseListParams_Defaults(t *testing.T) {
+	app := fiber.New()
+
+	var got service.ListParams
+	var parseErr error
+	app.Get("/", func(c *fiber.Ctx) error {
+		got, parseErr = parseListParams(c)
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+
+	if parseErr != nil {
+		t.Fatalf("parseListParams: %v", parseErr)
+	}
+	if got.Limit != service.DefaultLimit {
+		t.Errorf("got limit %d, want default %d", got.Limit, service.DefaultLimit)
+	}
+	if got.Offset != 0 {
+		t.Errorf("got offset %d, want 0", got.Offset)
+	}
+}
+
+func TestParseListParams_RejectsNonIntegerLimit(t *testing.T) {
+	app := fiber.New()
+
+	var parseErr error
+	app.Get("/", func(c *fiber.Ctx) error {
+		_, parseErr = parseListParams(c)
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/?limit=not-a-number", nil)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+
+	if parseErr == nil {
+		t.Fatal("expected an error for a non-integer limit")
+	}
+}
+
+func TestCacheExpenses_MatchingETagShortCircuits(t *testing.T) {
+	app := fiber.New()
+	lastEdit := time.Now()
+
+	var cached bool
+	app.Get("/", func(c *fiber.Ctx) error {
+		cached = cacheExpenses(c, lastEdit, string(c.Request().URI().QueryString()))
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if cached {
+		t.Fatal("expected first request without If-None-Match to not be cached")
+	}
+
+	req2 := httptest.NewRequest(http.MethodGet, "/", nil)
+	req2.Header.Set("If-None-Match", resp.Header.Get("ETag"))
+	if _, err := app.Test(req2); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if !cached {
+		t.Fatal("expected a request replaying the server's ETag to be reported as cached")
+	}
+}
+
+func TestCacheExpenses_DifferentQueriesDontShareAnETag(t *testing.T) {
+	app := fiber.New()
+	lastEdit := time.Now()
+
+	app.Get("/", func(c *fiber.Ctx) error {
+		cacheExpenses(c, lastEdit, string(c.Request().URI().QueryString()))
+		return nil
+	})
+
+	respA, err := app.Test(httptest.NewRequest(http.MethodGet, "/?limit=10", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	respB, err := app.Test(httptest.NewRequest(http.MethodGet, "/?limit=20", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+
+	if respA.Header.Get("ETag") == respB.Header.Get("ETag") {
+		t.Fatal("expected different queries to get different ETags for the same lastEdit")
+	}
+
+	var cached bool
+	app2 := fiber.New()
+	app2.Get("/", func(c *fiber.Ctx) error {
+		cached = cacheExpenses(c, lastEdit, string(c.Request().URI().QueryString()))
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/?limit=20", nil)
+	req.Header.Set("If-None-Match", respA.Header.Get("ETag"))
+	if _, err := app2.Test(req); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if cached {
+		t.Fatal("expected an ETag from a different query to not short-circuit this one")
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"fmt"
+
+	"expenses-tracker-go/service"
+)
+
+// halLink is a single HAL hypermedia link.
+type halLink struct {
+	Href string `json:"href"`
+}
+
+type halLinks map[string]halLink
+
+// expenseResource is a single expense enriched with its HAL links, so
+// clients can discover how to update or delete it without hardcoding routes.
+type expenseResource struct {
+	service.ExpenseDisplay
+	Links halLinks `json:"_links"`
+}
+
+// expenseCollection is the HAL+JSON envelope returned by GET /api/expenses.
+type expenseCollection struct {
+	Links    halLinks          `json:"_links"`
+	Total    int64             `json:"total"`
+	Summary  expenseSummaryDTO `json:"summary"`
+	Embedded struct {
+		Expenses []expenseResource `json:"expenses"`
+	} `json:"_embedded"`
+}
+
+type expenseSummaryDTO struct {
+	Sum float64 `json:"sum"`
+	Avg float64 `json:"avg"`
+}
+
+func expenseHref(id string) string {
+	return fmt.Sprintf("/api/expenses/%s", id)
+}
+
+func newExpenseResource(expense service.ExpenseDisplay) expenseResource {
+	href := expenseHref(expense.ID)
+
+	return expenseResource{
+		ExpenseDisplay: expense,
+		Links: halLinks{
+			"self":   {Href: href},
+			"update": {Href: href},
+			"delete": {Href: href},
+		},
+	}
+}
+
+// newExpenseCollection wraps a page of expenses, the matching total, and an
+// aggregate summary plus collection-level links into a HAL+JSON response.
+func newExpenseCollection(result service.ListResult) expenseCollection {
+	collection := expenseCollection{
+		Links: halLinks{
+			"self":       {Href: "/api/expenses"},
+			"collection": {Href: "/api/expenses"},
+		},
+		Total:   result.Total,
+		Summary: expenseSummaryDTO{Sum: result.Summary.Sum, Avg: result.Summary.Avg},
+	}
+
+	collection.Embedded.Expenses = make([]expenseResource, 0, len(result.Items))
+	for _, expense := range result.Items {
+		collection.Embedded.Expenses = append(collection.Embedded.Expenses, newExpenseResource(expense))
+	}
+
+	return collection
+}
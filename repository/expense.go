@@ -0,0 +1,216 @@
+// Package repository wraps every Mongo call the app makes against the
+// expenses collection behind an interface, so the service layer can be
+// tested without a live database.
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Expense is the document stored in the expenses collection.
+type Expense struct {
+	ID          bson.ObjectID `bson:"_id,omitempty"`
+	UserID      bson.ObjectID `bson:"userID"`
+	Description *string       `bson:"description"`
+	Cost        *float32      `bson:"cost"`
+	CreatedDate time.Time     `bson:"createdDate"`
+}
+
+// Filter narrows down which expenses a List/Count/Summary call considers.
+// UserID is always applied so a user only ever sees their own expenses;
+// the remaining zero-value fields are left out of the underlying query.
+type Filter struct {
+	UserID              bson.ObjectID
+	DescriptionContains string
+	MinCost             *float32
+	MaxCost             *float32
+	From                *time.Time
+	To                  *time.Time
+}
+
+// ListOptions controls pagination and ordering for List.
+type ListOptions struct {
+	Limit      int64
+	Offset     int64
+	SortColumn string
+	SortOrder  int
+}
+
+// Update holds the fields a PATCH may change. Nil fields are left untouched.
+type Update struct {
+	Description *string
+	Cost        *float32
+}
+
+// Summary is a server-computed aggregate over a set of expenses.
+type Summary struct {
+	Sum float64 `bson:"sum"`
+	Avg float64 `bson:"avg"`
+}
+
+// ExpenseRepository is the persistence boundary for expenses, implemented
+// against Mongo in production and easily faked in service-level tests.
+type ExpenseRepository interface {
+	List(ctx context.Context, filter Filter, opts ListOptions) ([]Expense, error)
+	Count(ctx context.Context, filter Filter) (int64, error)
+	Summary(ctx context.Context, filter Filter) (Summary, error)
+	Create(ctx context.Context, expense *Expense) error
+	// Update returns a nil Expense (with a nil error) if no expense with
+	// that id and userID exists, mirroring Delete's bool "did anything
+	// happen" signal instead of leaking a driver-level not-found error.
+	Update(ctx context.Context, userID, id bson.ObjectID, update Update) (*Expense, error)
+	Delete(ctx context.Context, userID, id bson.ObjectID) (bool, error)
+	// DeleteAllForUser removes every expense owned by userID, for cleaning up
+	// after an account deletion.
+	DeleteAllForUser(ctx context.Context, userID bson.ObjectID) error
+}
+
+type mongoExpenseRepository struct {
+	coll *mongo.Collection
+}
+
+// NewMongoExpenseRepository returns an ExpenseRepository backed by the given
+// Mongo collection.
+func NewMongoExpenseRepository(coll *mongo.Collection) ExpenseRepository {
+	return &mongoExpenseRepository{coll: coll}
+}
+
+func (r *mongoExpenseRepository) List(ctx context.Context, filter Filter, opts ListOptions) ([]Expense, error) {
+	cursor, err := r.coll.Find(ctx, toBsonFilter(filter), toFindOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var expenses []Expense
+	if err := cursor.All(ctx, &expenses); err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+func (r *mongoExpenseRepository) Count(ctx context.Context, filter Filter) (int64, error) {
+	return r.coll.CountDocuments(ctx, toBsonFilter(filter))
+}
+
+func (r *mongoExpenseRepository) Summary(ctx context.Context, filter Filter) (Summary, error) {
+	cursor, err := r.coll.Aggregate(ctx, mongo.Pipeline{
+		bson.D{{Key: "$match", Value: toBsonFilter(filter)}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "sum", Value: bson.D{{Key: "$sum", Value: "$cost"}}},
+			{Key: "avg", Value: bson.D{{Key: "$avg", Value: "$cost"}}},
+		}}},
+	})
+	if err != nil {
+		return Summary{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []Summary
+	if err := cursor.All(ctx, &rows); err != nil {
+		return Summary{}, err
+	}
+	if len(rows) == 0 {
+		return Summary{}, nil
+	}
+
+	return rows[0], nil
+}
+
+func (r *mongoExpenseRepository) Create(ctx context.Context, expense *Expense) error {
+	result, err := r.coll.InsertOne(ctx, expense)
+	if err != nil {
+		return err
+	}
+
+	expense.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+func (r *mongoExpenseRepository) Update(ctx context.Context, userID, id bson.ObjectID, update Update) (*Expense, error) {
+	set := bson.M{}
+	if update.Description != nil {
+		set["description"] = update.Description
+	}
+	if update.Cost != nil {
+		set["cost"] = update.Cost
+	}
+
+	updated := &Expense{}
+	err := r.coll.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": id, "userID": userID},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (r *mongoExpenseRepository) Delete(ctx context.Context, userID, id bson.ObjectID) (bool, error) {
+	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": id, "userID": userID})
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+func (r *mongoExpenseRepository) DeleteAllForUser(ctx context.Context, userID bson.ObjectID) error {
+	_, err := r.coll.DeleteMany(ctx, bson.M{"userID": userID})
+	return err
+}
+
+func toBsonFilter(filter Filter) bson.M {
+	query := bson.M{"userID": filter.UserID}
+
+	if filter.DescriptionContains != "" {
+		query["description"] = bson.M{"$regex": regexp.QuoteMeta(filter.DescriptionContains), "$options": "i"}
+	}
+
+	costRange := bson.M{}
+	if filter.MinCost != nil {
+		costRange["$gte"] = *filter.MinCost
+	}
+	if filter.MaxCost != nil {
+		costRange["$lte"] = *filter.MaxCost
+	}
+	if len(costRange) > 0 {
+		query["cost"] = costRange
+	}
+
+	dateRange := bson.M{}
+	if filter.From != nil {
+		dateRange["$gte"] = *filter.From
+	}
+	if filter.To != nil {
+		dateRange["$lte"] = *filter.To
+	}
+	if len(dateRange) > 0 {
+		query["createdDate"] = dateRange
+	}
+
+	return query
+}
+
+func toFindOptions(opts ListOptions) *options.FindOptionsBuilder {
+	return options.Find().
+		SetSkip(opts.Offset).
+		SetLimit(opts.Limit).
+		SetSort(bson.D{{Key: opts.SortColumn, Value: opts.SortOrder}})
+}
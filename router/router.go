@@ -0,0 +1,25 @@
+// Package router wires the Fiber app's routes to their handlers.
+package router
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"expenses-tracker-go/handler"
+	"expenses-tracker-go/user"
+)
+
+// Setup registers every auth and expenses route on app. auth is the
+// middleware that protects the per-user expense routes and account
+// deletion.
+func Setup(app *fiber.App, expenses *handler.ExpenseHandler, users *user.Handler, auth fiber.Handler) {
+	app.Post("/api/auth/register", users.Register)
+	app.Post("/api/auth/login", users.Login)
+	app.Post("/api/auth/refresh", users.Refresh)
+	app.Delete("/api/auth/account", auth, users.DeleteAccount)
+
+	app.Get("/api/expenses", auth, expenses.GetExpenses)
+	app.Get("/api/expenses/stream", auth, expenses.StreamExpenses)
+	app.Post("/api/expenses", auth, expenses.CreateExpense)
+	app.Patch("/api/expenses/:id", auth, expenses.UpdateExpense)
+	app.Delete("/api/expenses/:id", auth, expenses.RemoveExpense)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Event is published whenever an expense is created, updated, or deleted, so
+// subscribers (e.g. the SSE handler) can stay in sync without polling.
+type Event struct {
+	Object  string
+	Action  string
+	Expense *ExpenseDisplay
+	ID      string
+	UserID  bson.ObjectID
+	Source  string
+}
+
+type subscriber struct {
+	ch     chan Event
+	userID bson.ObjectID
+	source string
+}
+
+// broker fans out expense events to any number of concurrently connected
+// subscribers, each with its own buffered channel so a slow one can't stall
+// delivery to the others.
+type broker struct {
+	mu          sync.Mutex
+	subscribers map[string]*subscriber
+}
+
+func newBroker() *broker {
+	return &broker{subscribers: make(map[string]*subscriber)}
+}
+
+// subscribe registers a new subscriber, scoped to userID's own events.
+// source, when non-empty, is matched against the Source of published events
+// so a client doesn't receive echoes of its own edits.
+func (b *broker) subscribe(userID bson.ObjectID, source string) (string, chan Event) {
+	id := uuid.NewString()
+	sub := &subscriber{ch: make(chan Event, 16), userID: userID, source: source}
+
+	b.mu.Lock()
+	b.subscribers[id] = sub
+	b.mu.Unlock()
+
+	return id, sub.ch
+}
+
+func (b *broker) unsubscribe(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if sub, ok := b.subscribers[id]; ok {
+		delete(b.subscribers, id)
+		close(sub.ch)
+	}
+}
+
+func (b *broker) publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subscribers {
+		if sub.userID != event.UserID {
+			continue
+		}
+		if event.Source != "" && event.Source == sub.source {
+			continue
+		}
+
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,288 @@
+// Package service holds the expense business rules: input validation, the
+// live-update event bus, and translation between the repository's stored
+// documents and the DTOs the handlers expose over HTTP.
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"expenses-tracker-go/repository"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+var SortColumns = map[string]string{
+	"description": "description",
+	"cost":        "cost",
+	"createdDate": "createdDate",
+}
+
+var (
+	ErrCostNotPositive   = errors.New("`cost` must be a positive number")
+	ErrDescriptionEmpty  = errors.New("`description` must not be empty")
+	ErrInvalidID         = errors.New("`id` is not a valid expense id")
+	ErrNotFound          = errors.New("no expense with such `id`")
+	ErrInvalidSortColumn = errors.New("`sort_column` must be one of description, cost, createdDate")
+	ErrInvalidSortOrder  = errors.New("`sort_order` must be `asc` or `desc`")
+	ErrInvalidLimit      = errors.New("`limit` must be between 0 and " + strconv.Itoa(MaxLimit))
+)
+
+// ExpenseCreateUpdate is the payload accepted by POST and PATCH. It is kept
+// separate from ExpenseDisplay so clients can never set server-owned fields
+// such as the id or createdDate.
+type ExpenseCreateUpdate struct {
+	Description *string  `json:"description"`
+	Cost        *float32 `json:"cost"`
+}
+
+// ExpenseDisplay is the shape every expense is rendered as on the way out.
+type ExpenseDisplay struct {
+	ID          string    `json:"_id"`
+	Description string    `json:"description"`
+	Cost        float32   `json:"cost"`
+	CreatedDate time.Time `json:"createdDate"`
+}
+
+// ListParams carries the already-parsed GET /api/expenses query parameters.
+type ListParams struct {
+	Limit               int64
+	Offset              int64
+	SortColumn          string
+	SortOrder           string
+	DescriptionContains string
+	MinCost             *float32
+	MaxCost             *float32
+	From                *time.Time
+	To                  *time.Time
+}
+
+// ListResult is what List returns: a page of expenses plus the totals the
+// dashboard needs.
+type ListResult struct {
+	Items   []ExpenseDisplay
+	Total   int64
+	Summary repository.Summary
+}
+
+// ExpenseService owns expense validation, persistence orchestration, and the
+// live-update event bus.
+type ExpenseService struct {
+	repo   repository.ExpenseRepository
+	broker *broker
+
+	mu        sync.Mutex
+	lastEdits map[bson.ObjectID]time.Time
+}
+
+// NewExpenseService wires an ExpenseService to its repository.
+func NewExpenseService(repo repository.ExpenseRepository) *ExpenseService {
+	return &ExpenseService{repo: repo, broker: newBroker(), lastEdits: make(map[bson.ObjectID]time.Time)}
+}
+
+// List returns a page of expenses matching params, along with the total
+// count and sum/avg aggregate over the full matching set.
+func (s *ExpenseService) List(ctx context.Context, userID bson.ObjectID, params ListParams) (ListResult, error) {
+	if params.Limit < 0 || params.Limit > MaxLimit {
+		return ListResult{}, ErrInvalidLimit
+	}
+	limit := params.Limit
+
+	sortColumn := "createdDate"
+	if params.SortColumn != "" {
+		column, ok := SortColumns[params.SortColumn]
+		if !ok {
+			return ListResult{}, ErrInvalidSortColumn
+		}
+		sortColumn = column
+	}
+
+	sortOrder := 1
+	switch params.SortOrder {
+	case "", "asc":
+		sortOrder = 1
+	case "desc":
+		sortOrder = -1
+	default:
+		return ListResult{}, ErrInvalidSortOrder
+	}
+
+	filter := repository.Filter{
+		UserID:              userID,
+		DescriptionContains: params.DescriptionContains,
+		MinCost:             params.MinCost,
+		MaxCost:             params.MaxCost,
+		From:                params.From,
+		To:                  params.To,
+	}
+	opts := repository.ListOptions{
+		Limit:      limit,
+		Offset:     params.Offset,
+		SortColumn: sortColumn,
+		SortOrder:  sortOrder,
+	}
+
+	// options.FindOptions treats a limit of 0 as "no limit", so a client
+	// asking for zero rows must be special-cased rather than forwarded to
+	// the repository, which would otherwise return every matching row.
+	var expenses []repository.Expense
+	var err error
+	if limit > 0 {
+		expenses, err = s.repo.List(ctx, filter, opts)
+		if err != nil {
+			return ListResult{}, err
+		}
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return ListResult{}, err
+	}
+
+	summary, err := s.repo.Summary(ctx, filter)
+	if err != nil {
+		return ListResult{}, err
+	}
+
+	items := make([]ExpenseDisplay, 0, len(expenses))
+	for _, expense := range expenses {
+		items = append(items, toDisplay(expense))
+	}
+
+	return ListResult{Items: items, Total: total, Summary: summary}, nil
+}
+
+// Create validates and stores a new expense owned by userID, then publishes
+// a create event.
+func (s *ExpenseService) Create(ctx context.Context, userID bson.ObjectID, in ExpenseCreateUpdate, source string) (*ExpenseDisplay, error) {
+	if in.Cost == nil || *in.Cost <= 0 {
+		return nil, ErrCostNotPositive
+	}
+	if in.Description == nil || *in.Description == "" {
+		return nil, ErrDescriptionEmpty
+	}
+
+	expense := &repository.Expense{
+		UserID:      userID,
+		Description: in.Description,
+		Cost:        in.Cost,
+		CreatedDate: time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	display := toDisplay(*expense)
+	s.touch(userID)
+	s.broker.publish(Event{Object: "expense", Action: "create", Expense: &display, UserID: userID, Source: source})
+
+	return &display, nil
+}
+
+// Update validates and applies a partial update to an expense owned by
+// userID, then publishes an update event with the resulting document.
+func (s *ExpenseService) Update(ctx context.Context, userID bson.ObjectID, id string, in ExpenseCreateUpdate, source string) (*ExpenseDisplay, error) {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+
+	update := repository.Update{}
+	if in.Cost != nil {
+		if *in.Cost <= 0 {
+			return nil, ErrCostNotPositive
+		}
+		update.Cost = in.Cost
+	}
+	if in.Description != nil {
+		if *in.Description == "" {
+			return nil, ErrDescriptionEmpty
+		}
+		update.Description = in.Description
+	}
+
+	updated, err := s.repo.Update(ctx, userID, objectID, update)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrNotFound
+	}
+
+	display := toDisplay(*updated)
+	s.touch(userID)
+	s.broker.publish(Event{Object: "expense", Action: "update", Expense: &display, UserID: userID, Source: source})
+
+	return &display, nil
+}
+
+// Delete removes an expense owned by userID and publishes a delete event.
+func (s *ExpenseService) Delete(ctx context.Context, userID bson.ObjectID, id string, source string) error {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrInvalidID
+	}
+
+	deleted, err := s.repo.Delete(ctx, userID, objectID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNotFound
+	}
+
+	s.touch(userID)
+	s.broker.publish(Event{Object: "expense", Action: "delete", ID: id, UserID: userID, Source: source})
+
+	return nil
+}
+
+// Subscribe registers a new live-feed subscriber scoped to userID's own
+// expenses. source, when non-empty, suppresses echoes of that subscriber's
+// own edits.
+func (s *ExpenseService) Subscribe(userID bson.ObjectID, source string) (string, chan Event) {
+	return s.broker.subscribe(userID, source)
+}
+
+// Unsubscribe removes a live-feed subscriber.
+func (s *ExpenseService) Unsubscribe(id string) {
+	s.broker.unsubscribe(id)
+}
+
+// LastModified reports when userID's own expenses last changed, for
+// conditional-request caching. Each user's edits only invalidate their own
+// cached responses.
+func (s *ExpenseService) LastModified(userID bson.ObjectID) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lastEdits[userID]
+}
+
+func (s *ExpenseService) touch(userID bson.ObjectID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastEdits[userID] = time.Now()
+}
+
+func toDisplay(expense repository.Expense) ExpenseDisplay {
+	display := ExpenseDisplay{ID: expense.ID.Hex(), CreatedDate: expense.CreatedDate}
+	if expense.Description != nil {
+		display.Description = *expense.Description
+	}
+	if expense.Cost != nil {
+		display.Cost = *expense.Cost
+	}
+
+	return display
+}
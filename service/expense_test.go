@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"expenses-tracker-go/repository"
+)
+
+// fakeExpenseRepository is an in-memory repository.ExpenseRepository, so the
+// service layer can be tested without a live Mongo instance.
+type fakeExpenseRepository struct {
+	expenses []repository.Expense
+
+	listCalled bool
+	listErr    error
+}
+
+func (f *fakeExpenseRepository) List(ctx context.Context, filter repository.Filter, opts repository.ListOptions) ([]repository.Expense, error) {
+	f.listCalled = true
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+
+	var out []repository.Expense
+	for _, e := range f.expenses {
+		if e.UserID == filter.UserID {
+			out = append(out, e)
+		}
+	}
+
+	return out, nil
+}
+
+func (f *fakeExpenseRepository) Count(ctx context.Context, filter repository.Filter) (int64, error) {
+	var n int64
+	for _, e := range f.expenses {
+		if e.UserID == filter.UserID {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+func (f *fakeExpenseRepository) Summary(ctx context.Context, filter repository.Filter) (repository.Summary, error) {
+	return repository.Summary{}, nil
+}
+
+func (f *fakeExpenseRepository) Create(ctx context.Context, expense *repository.Expense) error {
+	expense.ID = bson.NewObjectID()
+	f.expenses = append(f.expenses, *expense)
+	return nil
+}
+
+func (f *fakeExpenseRepository) Update(ctx context.Context, userID, id bson.ObjectID, update repository.Update) (*repository.Expense, error) {
+	for i, e := range f.expenses {
+		if e.ID == id && e.UserID == userID {
+			if update.Description != nil {
+				f.expenses[i].Description = update.Description
+			}
+			if update.Cost != nil {
+				f.expenses[i].Cost = update.Cost
+			}
+			return &f.expenses[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (f *fakeExpenseRepository) Delete(ctx context.Context, userID, id bson.ObjectID) (bool, error) {
+	for i, e := range f.expenses {
+		if e.ID == id && e.UserID == userID {
+			f.expenses = append(f.expenses[:i], f.expenses[i+1:]...)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (f *fakeExpenseRepository) DeleteAllForUser(ctx context.Context, userID bson.ObjectID) error {
+	var kept []repository.Expense
+	for _, e := range f.expenses {
+		if e.UserID != userID {
+			kept = append(kept, e)
+		}
+	}
+	f.expenses = kept
+
+	return nil
+}
+
+func cost(v float32) *float32 { return &v }
+func desc(v string) *string   { return &v }
+
+func TestCreate_RejectsNonPositiveCost(t *testing.T) {
+	svc := NewExpenseService(&fakeExpenseRepository{})
+	userID := bson.NewObjectID()
+
+	_, err := svc.Create(context.Background(), userID, ExpenseCreateUpdate{Cost: cost(0), Description: desc("lunch")}, "")
+	if !errors.Is(err, ErrCostNotPositive) {
+		t.Fatalf("got err %v, want ErrCostNotPositive", err)
+	}
+}
+
+func TestCreate_RejectsEmptyDescription(t *testing.T) {
+	svc := NewExpenseService(&fakeExpenseRepository{})
+	userID := bson.NewObjectID()
+
+	_, err := svc.Create(context.Background(), userID, ExpenseCreateUpdate{Cost: cost(5), Description: desc("")}, "")
+	if !errors.Is(err, ErrDescriptionEmpty) {
+		t.Fatalf("got err %v, want ErrDescriptionEmpty", err)
+	}
+}
+
+func TestUpdate_UnknownIDReturnsNotFound(t *testing.T) {
+	svc := NewExpenseService(&fakeExpenseRepository{})
+	userID := bson.NewObjectID()
+
+	_, err := svc.Update(context.Background(), userID, bson.NewObjectID().Hex(), ExpenseCreateUpdate{Cost: cost(5)}, "")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got err %v, want ErrNotFound", err)
+	}
+}
+
+func TestUpdate_AnotherUsersExpenseReturnsNotFound(t *testing.T) {
+	repo := &fakeExpenseRepository{}
+	owner := bson.NewObjectID()
+	other := bson.NewObjectID()
+
+	svc := NewExpenseService(repo)
+	created, err := svc.Create(context.Background(), owner, ExpenseCreateUpdate{Cost: cost(5), Description: desc("lunch")}, "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	_, err = svc.Update(context.Background(), other, created.ID, ExpenseCreateUpdate{Cost: cost(6)}, "")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got err %v, want ErrNotFound", err)
+	}
+}
+
+func TestList_RejectsLimitOutOfBounds(t *testing.T) {
+	svc := NewExpenseService(&fakeExpenseRepository{})
+	userID := bson.NewObjectID()
+
+	if _, err := svc.List(context.Background(), userID, ListParams{Limit: -1}); !errors.Is(err, ErrInvalidLimit) {
+		t.Fatalf("got err %v, want ErrInvalidLimit", err)
+	}
+	if _, err := svc.List(context.Background(), userID, ListParams{Limit: MaxLimit + 1}); !errors.Is(err, ErrInvalidLimit) {
+		t.Fatalf("got err %v, want ErrInvalidLimit", err)
+	}
+}
+
+func TestList_LimitZeroReturnsEmptyPageWithoutQueryingRepo(t *testing.T) {
+	repo := &fakeExpenseRepository{}
+	userID := bson.NewObjectID()
+	repo.expenses = append(repo.expenses, repository.Expense{ID: bson.NewObjectID(), UserID: userID, Description: desc("lunch"), Cost: cost(5)})
+
+	svc := NewExpenseService(repo)
+
+	result, err := svc.List(context.Background(), userID, ListParams{Limit: 0})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if repo.listCalled {
+		t.Fatal("List should not have been called against the repository for limit=0")
+	}
+	if len(result.Items) != 0 {
+		t.Fatalf("got %d items, want 0", len(result.Items))
+	}
+	if result.Total != 1 {
+		t.Fatalf("got total %d, want 1 (Count should still reflect the real filter)", result.Total)
+	}
+}
+
+func TestList_RejectsInvalidSortColumnAndOrder(t *testing.T) {
+	svc := NewExpenseService(&fakeExpenseRepository{})
+	userID := bson.NewObjectID()
+
+	if _, err := svc.List(context.Background(), userID, ListParams{Limit: DefaultLimit, SortColumn: "not-a-column"}); !errors.Is(err, ErrInvalidSortColumn) {
+		t.Fatalf("got err %v, want ErrInvalidSortColumn", err)
+	}
+	if _, err := svc.List(context.Background(), userID, ListParams{Limit: DefaultLimit, SortOrder: "sideways"}); !errors.Is(err, ErrInvalidSortOrder) {
+		t.Fatalf("got err %v, want ErrInvalidSortOrder", err)
+	}
+}
+
+func TestLastModified_IsScopedPerUser(t *testing.T) {
+	svc := NewExpenseService(&fakeExpenseRepository{})
+	userA := bson.NewObjectID()
+	userB := bson.NewObjectID()
+
+	if !svc.LastModified(userA).IsZero() {
+		t.Fatal("expected zero LastModified before any edits")
+	}
+
+	if _, err := svc.Create(context.Background(), userA, ExpenseCreateUpdate{Cost: cost(5), Description: desc("lunch")}, ""); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if svc.LastModified(userA).IsZero() {
+		t.Fatal("expected userA's LastModified to be set after userA's edit")
+	}
+	if !svc.LastModified(userB).IsZero() {
+		t.Fatal("userB's LastModified should be unaffected by userA's edit")
+	}
+}
@@ -0,0 +1,111 @@
+package user
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler exposes the auth API (register/login/refresh/account deletion) on
+// top of a Service.
+type Handler struct {
+	svc *Service
+}
+
+// NewHandler wires a Handler to its service.
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+type credentialsPayload struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type tokenPairResponse struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+func errorResp(err error) fiber.Map {
+	return fiber.Map{"success": false, "message": err.Error()}
+}
+
+func statusFor(err error) int {
+	switch err {
+	case ErrEmailTaken:
+		return fiber.StatusConflict
+	case ErrInvalidCredentials, ErrInvalidRefreshToken:
+		return fiber.StatusUnauthorized
+	default:
+		return fiber.StatusBadRequest
+	}
+}
+
+// Register handles POST /api/auth/register.
+func (h *Handler) Register(c *fiber.Ctx) error {
+	payload := credentialsPayload{}
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp(err))
+	}
+
+	tokens, err := h.svc.Register(c.Context(), Credentials{Email: payload.Email, Password: payload.Password})
+	if err != nil {
+		return c.Status(statusFor(err)).JSON(errorResp(err))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(tokenPairResponse{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken})
+}
+
+// Login handles POST /api/auth/login.
+func (h *Handler) Login(c *fiber.Ctx) error {
+	payload := credentialsPayload{}
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp(err))
+	}
+
+	tokens, err := h.svc.Login(c.Context(), Credentials{Email: payload.Email, Password: payload.Password})
+	if err != nil {
+		return c.Status(statusFor(err)).JSON(errorResp(err))
+	}
+
+	return c.JSON(tokenPairResponse{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken})
+}
+
+// Refresh handles POST /api/auth/refresh.
+func (h *Handler) Refresh(c *fiber.Ctx) error {
+	payload := struct {
+		RefreshToken string `json:"refreshToken"`
+	}{}
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp(err))
+	}
+
+	tokens, err := h.svc.Refresh(c.Context(), payload.RefreshToken)
+	if err != nil {
+		return c.Status(statusFor(err)).JSON(errorResp(err))
+	}
+
+	return c.JSON(tokenPairResponse{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken})
+}
+
+// DeleteAccount handles DELETE /api/auth/account. It requires the caller to
+// re-confirm their password, so a leaked access token alone can't be used
+// to destroy the account.
+func (h *Handler) DeleteAccount(c *fiber.Ctx) error {
+	userID, ok := UserIDFromContext(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+
+	payload := struct {
+		Password string `json:"password"`
+	}{}
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp(err))
+	}
+
+	if err := h.svc.DeleteAccount(c.Context(), userID, payload.Password); err != nil {
+		return c.Status(statusFor(err)).JSON(errorResp(err))
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
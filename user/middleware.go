@@ -0,0 +1,41 @@
+package user
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const contextUserIDKey = "userID"
+
+// Authenticate validates the Authorization header's bearer token and
+// injects the authenticated user's id into the request context.
+func Authenticate(svc *Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, ok := strings.CutPrefix(c.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"message": "missing or malformed `Authorization` header",
+			})
+		}
+
+		userID, err := svc.ParseAccessToken(token)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"message": "invalid or expired token",
+			})
+		}
+
+		c.Locals(contextUserIDKey, userID)
+		return c.Next()
+	}
+}
+
+// UserIDFromContext extracts the authenticated user's id set by Authenticate.
+func UserIDFromContext(c *fiber.Ctx) (bson.ObjectID, bool) {
+	userID, ok := c.Locals(contextUserIDKey).(bson.ObjectID)
+	return userID, ok
+}
@@ -0,0 +1,177 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"golang.org/x/crypto/bcrypt"
+
+	"expenses-tracker-go/repository"
+)
+
+const minPasswordLength = 8
+
+var (
+	ErrEmailTaken          = errors.New("an account with this `email` already exists")
+	ErrEmailInvalid        = errors.New("`email` must not be empty and must contain an `@`")
+	ErrPasswordTooShort    = errors.New("`password` must be at least " + strconv.Itoa(minPasswordLength) + " characters")
+	ErrInvalidCredentials  = errors.New("invalid `email` or `password`")
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid or expired")
+)
+
+// Credentials is the email/password pair used to register and log in.
+type Credentials struct {
+	Email    string
+	Password string
+}
+
+// TokenPair is the access/refresh token pair issued on register, login, and
+// refresh.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// Service owns user registration, authentication, and token issuance.
+type Service struct {
+	repo     Repository
+	expenses repository.ExpenseRepository
+	secret   []byte
+}
+
+// NewService wires a Service to its repository, the expense repository (so
+// account deletion can clean up after itself), and its JWT signing secret.
+func NewService(repo Repository, expenses repository.ExpenseRepository, secret []byte) *Service {
+	return &Service{repo: repo, expenses: expenses, secret: secret}
+}
+
+// Register creates a new account and returns an initial token pair.
+// Email uniqueness is enforced by the repository's unique index rather than
+// a check-then-insert here, so two concurrent registrations for the same
+// email can't both succeed.
+func (s *Service) Register(ctx context.Context, creds Credentials) (TokenPair, error) {
+	if err := validateCredentials(creds); err != nil {
+		return TokenPair{}, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(creds.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	u := &User{Email: creds.Email, PasswordHash: string(hash)}
+	if err := s.repo.CreateUser(ctx, u); err != nil {
+		return TokenPair{}, err
+	}
+
+	return s.issueTokenPair(ctx, u.ID)
+}
+
+func validateCredentials(creds Credentials) error {
+	if strings.TrimSpace(creds.Email) == "" || !strings.Contains(creds.Email, "@") {
+		return ErrEmailInvalid
+	}
+	if len(creds.Password) < minPasswordLength {
+		return ErrPasswordTooShort
+	}
+
+	return nil
+}
+
+// Login verifies credentials and returns a fresh token pair.
+func (s *Service) Login(ctx context.Context, creds Credentials) (TokenPair, error) {
+	u, err := s.repo.FindUserByEmail(ctx, creds.Email)
+	if err != nil {
+		return TokenPair{}, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(creds.Password)); err != nil {
+		return TokenPair{}, ErrInvalidCredentials
+	}
+
+	return s.issueTokenPair(ctx, u.ID)
+}
+
+// Refresh exchanges a still-valid refresh token for a new token pair,
+// rotating the refresh token so it can only be used once.
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (TokenPair, error) {
+	hash := hashToken(refreshToken)
+
+	stored, err := s.repo.FindRefreshToken(ctx, hash)
+	if err != nil || stored.ExpiresAt.Before(time.Now()) {
+		return TokenPair{}, ErrInvalidRefreshToken
+	}
+
+	if err := s.repo.DeleteRefreshToken(ctx, hash); err != nil {
+		return TokenPair{}, err
+	}
+
+	return s.issueTokenPair(ctx, stored.UserID)
+}
+
+// DeleteAccount removes the account, requiring the current password so a
+// leaked access token alone can't be used to destroy it. It also deletes
+// every expense the account owns, so nothing is left behind under a userID
+// that can never authenticate again.
+func (s *Service) DeleteAccount(ctx context.Context, userID bson.ObjectID, password string) error {
+	u, err := s.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)); err != nil {
+		return ErrInvalidCredentials
+	}
+
+	if err := s.expenses.DeleteAllForUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteRefreshTokensForUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteUser(ctx, userID)
+}
+
+// ParseAccessToken validates an access token and returns the user id it
+// carries.
+func (s *Service) ParseAccessToken(raw string) (bson.ObjectID, error) {
+	subject, err := parseAccessToken(s.secret, raw)
+	if err != nil {
+		return bson.ObjectID{}, err
+	}
+
+	return bson.ObjectIDFromHex(subject)
+}
+
+func (s *Service) issueTokenPair(ctx context.Context, userID bson.ObjectID) (TokenPair, error) {
+	access, err := issueAccessToken(s.secret, userID.Hex())
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	refresh := uuid.NewString()
+	token := &RefreshToken{
+		UserID:    userID,
+		TokenHash: hashToken(refresh),
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}
+	if err := s.repo.CreateRefreshToken(ctx, token); err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{AccessToken: access, RefreshToken: refresh}, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
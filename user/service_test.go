@@ -0,0 +1,236 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"golang.org/x/crypto/bcrypt"
+
+	"expenses-tracker-go/repository"
+)
+
+// fakeRepository is an in-memory Repository, so Service can be tested
+// without a live Mongo instance.
+type fakeRepository struct {
+	usersByID    map[bson.ObjectID]*User
+	usersByEmail map[string]*User
+	refresh      map[string]*RefreshToken
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		usersByID:    make(map[bson.ObjectID]*User),
+		usersByEmail: make(map[string]*User),
+		refresh:      make(map[string]*RefreshToken),
+	}
+}
+
+// CreateUser mimics the atomicity a unique index on email gives the real
+// repository: the existence check and the insert happen as one step.
+func (f *fakeRepository) CreateUser(ctx context.Context, u *User) error {
+	if _, taken := f.usersByEmail[u.Email]; taken {
+		return ErrEmailTaken
+	}
+
+	u.ID = bson.NewObjectID()
+	f.usersByID[u.ID] = u
+	f.usersByEmail[u.Email] = u
+	return nil
+}
+
+func (f *fakeRepository) FindUserByEmail(ctx context.Context, email string) (*User, error) {
+	u, ok := f.usersByEmail[email]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return u, nil
+}
+
+func (f *fakeRepository) FindUserByID(ctx context.Context, id bson.ObjectID) (*User, error) {
+	u, ok := f.usersByID[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return u, nil
+}
+
+func (f *fakeRepository) DeleteUser(ctx context.Context, id bson.ObjectID) error {
+	if u, ok := f.usersByID[id]; ok {
+		delete(f.usersByEmail, u.Email)
+		delete(f.usersByID, id)
+	}
+	return nil
+}
+
+func (f *fakeRepository) CreateRefreshToken(ctx context.Context, token *RefreshToken) error {
+	token.ID = bson.NewObjectID()
+	f.refresh[token.TokenHash] = token
+	return nil
+}
+
+func (f *fakeRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	token, ok := f.refresh[tokenHash]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return token, nil
+}
+
+func (f *fakeRepository) DeleteRefreshToken(ctx context.Context, tokenHash string) error {
+	delete(f.refresh, tokenHash)
+	return nil
+}
+
+func (f *fakeRepository) DeleteRefreshTokensForUser(ctx context.Context, userID bson.ObjectID) error {
+	for hash, token := range f.refresh {
+		if token.UserID == userID {
+			delete(f.refresh, hash)
+		}
+	}
+	return nil
+}
+
+// fakeExpenseRepository is a minimal repository.ExpenseRepository that only
+// tracks which userID DeleteAllForUser was called with.
+type fakeExpenseRepository struct {
+	deletedForUser []bson.ObjectID
+}
+
+func (f *fakeExpenseRepository) List(ctx context.Context, filter repository.Filter, opts repository.ListOptions) ([]repository.Expense, error) {
+	return nil, nil
+}
+func (f *fakeExpenseRepository) Count(ctx context.Context, filter repository.Filter) (int64, error) {
+	return 0, nil
+}
+func (f *fakeExpenseRepository) Summary(ctx context.Context, filter repository.Filter) (repository.Summary, error) {
+	return repository.Summary{}, nil
+}
+func (f *fakeExpenseRepository) Create(ctx context.Context, expense *repository.Expense) error {
+	return nil
+}
+func (f *fakeExpenseRepository) Update(ctx context.Context, userID, id bson.ObjectID, update repository.Update) (*repository.Expense, error) {
+	return nil, nil
+}
+func (f *fakeExpenseRepository) Delete(ctx context.Context, userID, id bson.ObjectID) (bool, error) {
+	return false, nil
+}
+func (f *fakeExpenseRepository) DeleteAllForUser(ctx context.Context, userID bson.ObjectID) error {
+	f.deletedForUser = append(f.deletedForUser, userID)
+	return nil
+}
+
+func newTestService(repo Repository, expenses repository.ExpenseRepository) *Service {
+	return NewService(repo, expenses, []byte("test-secret"))
+}
+
+func TestRegister_RejectsTakenEmail(t *testing.T) {
+	repo := newFakeRepository()
+	svc := newTestService(repo, &fakeExpenseRepository{})
+
+	ctx := context.Background()
+	if _, err := svc.Register(ctx, Credentials{Email: "a@example.com", Password: "hunter22"}); err != nil {
+		t.Fatalf("first Register: %v", err)
+	}
+
+	if _, err := svc.Register(ctx, Credentials{Email: "a@example.com", Password: "different"}); !errors.Is(err, ErrEmailTaken) {
+		t.Fatalf("got err %v, want ErrEmailTaken", err)
+	}
+}
+
+func TestRegister_RejectsInvalidEmailAndShortPassword(t *testing.T) {
+	svc := newTestService(newFakeRepository(), &fakeExpenseRepository{})
+	ctx := context.Background()
+
+	if _, err := svc.Register(ctx, Credentials{Email: "", Password: "longenough"}); !errors.Is(err, ErrEmailInvalid) {
+		t.Fatalf("got err %v, want ErrEmailInvalid", err)
+	}
+	if _, err := svc.Register(ctx, Credentials{Email: "not-an-email", Password: "longenough"}); !errors.Is(err, ErrEmailInvalid) {
+		t.Fatalf("got err %v, want ErrEmailInvalid", err)
+	}
+	if _, err := svc.Register(ctx, Credentials{Email: "a@example.com", Password: "short"}); !errors.Is(err, ErrPasswordTooShort) {
+		t.Fatalf("got err %v, want ErrPasswordTooShort", err)
+	}
+}
+
+func TestLogin_RejectsWrongPassword(t *testing.T) {
+	repo := newFakeRepository()
+	svc := newTestService(repo, &fakeExpenseRepository{})
+
+	ctx := context.Background()
+	if _, err := svc.Register(ctx, Credentials{Email: "a@example.com", Password: "hunter22"}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	if _, err := svc.Login(ctx, Credentials{Email: "a@example.com", Password: "wrong"}); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("got err %v, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestDeleteAccount_RejectsWrongPassword(t *testing.T) {
+	repo := newFakeRepository()
+	svc := newTestService(repo, &fakeExpenseRepository{})
+
+	ctx := context.Background()
+	pair, err := svc.Register(ctx, Credentials{Email: "a@example.com", Password: "hunter22"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	userID, err := svc.ParseAccessToken(pair.AccessToken)
+	if err != nil {
+		t.Fatalf("ParseAccessToken: %v", err)
+	}
+
+	if err := svc.DeleteAccount(ctx, userID, "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("got err %v, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestDeleteAccount_CleansUpOwnedExpenses(t *testing.T) {
+	repo := newFakeRepository()
+	expenses := &fakeExpenseRepository{}
+	svc := newTestService(repo, expenses)
+
+	ctx := context.Background()
+	pair, err := svc.Register(ctx, Credentials{Email: "a@example.com", Password: "hunter22"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	userID, err := svc.ParseAccessToken(pair.AccessToken)
+	if err != nil {
+		t.Fatalf("ParseAccessToken: %v", err)
+	}
+
+	if err := svc.DeleteAccount(ctx, userID, "hunter22"); err != nil {
+		t.Fatalf("DeleteAccount: %v", err)
+	}
+
+	if len(expenses.deletedForUser) != 1 || expenses.deletedForUser[0] != userID {
+		t.Fatalf("got DeleteAllForUser calls %v, want exactly one for %v", expenses.deletedForUser, userID)
+	}
+
+	if _, err := repo.FindUserByID(ctx, userID); err == nil {
+		t.Fatal("expected user to be deleted")
+	}
+}
+
+func TestDeleteAccount_UsesBcryptToVerifyPassword(t *testing.T) {
+	repo := newFakeRepository()
+	hash, err := bcrypt.GenerateFromPassword([]byte("hunter22"), bcrypt.DefaultCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword: %v", err)
+	}
+	u := &User{Email: "a@example.com", PasswordHash: string(hash)}
+	if err := repo.CreateUser(context.Background(), u); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	svc := newTestService(repo, &fakeExpenseRepository{})
+
+	if err := svc.DeleteAccount(context.Background(), u.ID, "hunter22"); err != nil {
+		t.Fatalf("DeleteAccount: %v", err)
+	}
+}
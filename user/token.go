@@ -0,0 +1,48 @@
+package user
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
+var errInvalidToken = errors.New("token is invalid or expired")
+
+type accessClaims struct {
+	jwt.RegisteredClaims
+}
+
+func issueAccessToken(secret []byte, userID string) (string, error) {
+	now := time.Now()
+	claims := accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL)),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+func parseAccessToken(secret []byte, raw string) (string, error) {
+	token, err := jwt.ParseWithClaims(raw, &accessClaims{}, func(t *jwt.Token) (interface{}, error) {
+		return secret, nil
+	})
+	if err != nil || !token.Valid {
+		return "", errInvalidToken
+	}
+
+	claims, ok := token.Claims.(*accessClaims)
+	if !ok {
+		return "", errInvalidToken
+	}
+
+	return claims.Subject, nil
+}
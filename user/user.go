@@ -0,0 +1,126 @@
+// Package user handles registration, login, refresh tokens, and the
+// authentication middleware that scopes every expense to its owner.
+package user
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// User is the document stored in the users collection.
+type User struct {
+	ID           bson.ObjectID `bson:"_id,omitempty"`
+	Email        string        `bson:"email"`
+	PasswordHash string        `bson:"passwordHash"`
+}
+
+// RefreshToken is a stored, hashed refresh token that can be exchanged for
+// a new access token until it expires or is used.
+type RefreshToken struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	UserID    bson.ObjectID `bson:"userID"`
+	TokenHash string        `bson:"tokenHash"`
+	ExpiresAt time.Time     `bson:"expiresAt"`
+}
+
+// Repository is the persistence boundary for users and their refresh
+// tokens, implemented against Mongo in production.
+type Repository interface {
+	CreateUser(ctx context.Context, u *User) error
+	FindUserByEmail(ctx context.Context, email string) (*User, error)
+	FindUserByID(ctx context.Context, id bson.ObjectID) (*User, error)
+	DeleteUser(ctx context.Context, id bson.ObjectID) error
+
+	CreateRefreshToken(ctx context.Context, token *RefreshToken) error
+	FindRefreshToken(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	DeleteRefreshToken(ctx context.Context, tokenHash string) error
+	DeleteRefreshTokensForUser(ctx context.Context, userID bson.ObjectID) error
+}
+
+type mongoRepository struct {
+	users         *mongo.Collection
+	refreshTokens *mongo.Collection
+}
+
+// NewMongoRepository returns a Repository backed by the given Mongo
+// collections. It ensures a unique index on users.email so that concurrent
+// registrations for the same email can't both succeed.
+func NewMongoRepository(users, refreshTokens *mongo.Collection) Repository {
+	if _, err := users.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		panic(err)
+	}
+
+	return &mongoRepository{users: users, refreshTokens: refreshTokens}
+}
+
+func (r *mongoRepository) CreateUser(ctx context.Context, u *User) error {
+	result, err := r.users.InsertOne(ctx, u)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrEmailTaken
+	}
+	if err != nil {
+		return err
+	}
+
+	u.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+func (r *mongoRepository) FindUserByEmail(ctx context.Context, email string) (*User, error) {
+	u := &User{}
+	if err := r.users.FindOne(ctx, bson.M{"email": email}).Decode(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+func (r *mongoRepository) FindUserByID(ctx context.Context, id bson.ObjectID) (*User, error) {
+	u := &User{}
+	if err := r.users.FindOne(ctx, bson.M{"_id": id}).Decode(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+func (r *mongoRepository) DeleteUser(ctx context.Context, id bson.ObjectID) error {
+	_, err := r.users.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *mongoRepository) CreateRefreshToken(ctx context.Context, token *RefreshToken) error {
+	result, err := r.refreshTokens.InsertOne(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	token.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+func (r *mongoRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	token := &RefreshToken{}
+	if err := r.refreshTokens.FindOne(ctx, bson.M{"tokenHash": tokenHash}).Decode(token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *mongoRepository) DeleteRefreshToken(ctx context.Context, tokenHash string) error {
+	_, err := r.refreshTokens.DeleteOne(ctx, bson.M{"tokenHash": tokenHash})
+	return err
+}
+
+func (r *mongoRepository) DeleteRefreshTokensForUser(ctx context.Context, userID bson.ObjectID) error {
+	_, err := r.refreshTokens.DeleteMany(ctx, bson.M{"userID": userID})
+	return err
+}